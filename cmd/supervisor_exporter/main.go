@@ -0,0 +1,35 @@
+// Command supervisor_exporter scrapes a supervisord instance over XML-RPC and
+// serves the result as Prometheus metrics.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/sflechie/go-supervisor/supervisor"
+	"github.com/sflechie/go-supervisor/supervisor/exporter"
+)
+
+func main() {
+	var (
+		listenAddress = flag.String("web.listen-address", ":9479", "Address to listen on for telemetry.")
+		metricsPath   = flag.String("web.telemetry-path", "/metrics", "Path under which to expose metrics.")
+		supervisorURL = flag.String("supervisor.url", "http://localhost:9001/RPC2", "URL of the supervisord XML-RPC endpoint (supports unix:// sockets).")
+	)
+	flag.Parse()
+
+	client, err := supervisor.NewClient(*supervisorURL)
+	if err != nil {
+		log.Fatalf("connecting to supervisord at %s: %v", *supervisorURL, err)
+	}
+
+	prometheus.MustRegister(exporter.New(client))
+
+	http.Handle(*metricsPath, promhttp.Handler())
+	log.Printf("listening on %s, serving metrics at %s", *listenAddress, *metricsPath)
+	log.Fatal(http.ListenAndServe(*listenAddress, nil))
+}
@@ -0,0 +1,364 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// ctxTransport wraps an http.RoundTripper and binds the next request it
+// proxies to a caller-supplied context. kolo/xmlrpc builds its *http.Request
+// without a context, so this is the only hook available to make a ctx
+// cancellation actually abort the in-flight POST instead of merely letting
+// the caller stop waiting on it. call() holds callMu for the full RPC round
+// trip, which serializes Context calls issued concurrently against the same
+// Client; that is the price of retrofitting cancellation onto a client that
+// was never built to carry one. ctx itself is guarded by the separate
+// fieldMu, which is only ever held briefly: call() runs fn() synchronously
+// on the same goroutine, and fn() calls back into RoundTrip, so fieldMu must
+// not still be held at that point or RoundTrip's Lock would deadlock against
+// itself.
+type ctxTransport struct {
+	base    http.RoundTripper
+	callMu  sync.Mutex
+	fieldMu sync.Mutex
+	ctx     context.Context
+}
+
+func (t *ctxTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.fieldMu.Lock()
+	ctx := t.ctx
+	t.fieldMu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return t.base.RoundTrip(req.WithContext(ctx))
+}
+
+func (t *ctxTransport) setCtx(ctx context.Context) {
+	t.fieldMu.Lock()
+	t.ctx = ctx
+	t.fieldMu.Unlock()
+}
+
+func (t *ctxTransport) call(ctx context.Context, fn func() error) error {
+	t.callMu.Lock()
+	defer t.callMu.Unlock()
+	t.setCtx(ctx)
+	defer t.setCtx(context.Background())
+	return fn()
+}
+
+// callContext runs fn, binding it to ctx when the Client was built by
+// NewClientContext, or running it unmodified otherwise.
+func (client Client) callContext(ctx context.Context, fn func() error) error {
+	if client.ctxTransport == nil {
+		return fn()
+	}
+	return client.ctxTransport.call(ctx, fn)
+}
+
+// NewClientContext creates a new supervisor RPC client whose *Context methods
+// bind each call to the ctx passed to that method. ctx itself bounds only the
+// initial API version check. It accepts the same Options as NewClient (WithBasicAuth,
+// WithTLSConfig, WithHTTPClient, WithTransport, WithAPIVersionCheck).
+func NewClientContext(ctx context.Context, url string, opts ...Option) (client Client, err error) {
+	var rpc *xmlrpc.Client
+
+	resolvedURL, cfg := newClientConfig(url, opts)
+
+	base := cfg.roundTripper()
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ct := &ctxTransport{base: base}
+
+	if rpc, err = xmlrpc.NewClient(resolvedURL, ct); err != nil {
+		return
+	}
+
+	version := ""
+	if err = ct.call(ctx, func() error {
+		return rpc.Call("supervisor.getAPIVersion", nil, &version)
+	}); err != nil {
+		return
+	}
+	if cfg.checkAPIVersion && version != apiVersion {
+		err = errors.New(fmt.Sprintf("want Supervisor API version %s, got %s instead", apiVersion, version))
+		return
+	}
+	client = Client{RpcClient: rpc, ApiVersion: version, ctxTransport: ct}
+	return
+}
+
+// GetSupervisorVersionContext returns the Supervisor version we connect to.
+func (client Client) GetSupervisorVersionContext(ctx context.Context) (version string, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.getSupervisorVersion", nil, &version))
+	})
+	return
+}
+
+// GetIdentificationContext returns the Supervisor ID string.
+func (client Client) GetIdentificationContext(ctx context.Context) (id string, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.getIdentification", nil, &id))
+	})
+	return
+}
+
+// GetStateContext returns the Supervisor process state.
+func (client Client) GetStateContext(ctx context.Context) (state *SupervisorState, err error) {
+	result := make(map[string]interface{})
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.getState", nil, &result))
+	})
+	if err == nil {
+		state = newSupervisorState(result)
+	}
+	return
+}
+
+// GetPIDContext returns the Supervisor process PID.
+func (client Client) GetPIDContext(ctx context.Context) (pid int64, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.getPID", nil, &pid))
+	})
+	return
+}
+
+// ClearLogContext clears the Supervisor process log.
+func (client Client) ClearLogContext(ctx context.Context) (result bool, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.clearLog", nil, &result))
+	})
+	return
+}
+
+// ShutdownContext shuts down the Supervisor process.
+func (client Client) ShutdownContext(ctx context.Context) (result bool, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.shutdown", nil, &result))
+	})
+	return
+}
+
+// RestartContext restarts the Supervisor process.
+func (client Client) RestartContext(ctx context.Context) (result bool, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.restart", nil, &result))
+	})
+	return
+}
+
+// ReloadConfigContext reloads the Supervisor configuration.
+func (client Client) ReloadConfigContext(ctx context.Context) (info ReloadInfo, err error) {
+	var results []interface{}
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.reloadConfig", nil, &results))
+	})
+	if err == nil {
+		info = newReloadInfo(results[0].([]interface{}))
+	}
+	return
+}
+
+// GetProcessInfoContext retrieves information for a particular Supervisor process.
+func (client Client) GetProcessInfoContext(ctx context.Context, name string) (info ProcessInfo, err error) {
+	result := make(map[string]interface{})
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.getProcessInfo", name, &result))
+	})
+	if err == nil {
+		info = newProcessInfo(result)
+	}
+	return
+}
+
+// GetAllProcessInfoContext retrieves information for all Supervisor processes.
+func (client Client) GetAllProcessInfoContext(ctx context.Context) (info []ProcessInfo, err error) {
+	var results []interface{}
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.getAllProcessInfo", nil, &results))
+	})
+	if err == nil {
+		info = make([]ProcessInfo, len(results))
+		for i, result := range results {
+			info[i] = newProcessInfo(result.(map[string]interface{}))
+		}
+	}
+	return
+}
+
+// StartProcessContext tells Supervisor to start the named process.
+func (client Client) StartProcessContext(ctx context.Context, name string, wait bool) (result bool, err error) {
+	params := makeParams(name, wait)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.startProcess", params, &result))
+	})
+	return
+}
+
+// StopProcessContext tells Supervisor to stop the named process.
+func (client Client) StopProcessContext(ctx context.Context, name string, wait bool) (result bool, err error) {
+	params := makeParams(name, wait)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.stopProcess", params, &result))
+	})
+	return
+}
+
+// StartAllProcessesContext tells Supervisor to start all stopped processes.
+func (client Client) StartAllProcessesContext(ctx context.Context, wait bool) (info []ProcessStatus, err error) {
+	var results []interface{}
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.startAllProcesses", wait, &results))
+	})
+	if err == nil {
+		info = make([]ProcessStatus, len(results))
+		for i, result := range results {
+			info[i] = newProcessStatus(result.(map[string]interface{}))
+		}
+	}
+	return
+}
+
+// StopAllProcessesContext tells Supervisor to stop all running processes.
+func (client Client) StopAllProcessesContext(ctx context.Context, wait bool) (info []ProcessStatus, err error) {
+	var results []interface{}
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.stopAllProcesses", wait, &results))
+	})
+	if err == nil {
+		info = make([]ProcessStatus, len(results))
+		for i, result := range results {
+			info[i] = newProcessStatus(result.(map[string]interface{}))
+		}
+	}
+	return
+}
+
+// StartProcessGroupContext tells Supervisor to start all stopped processes in the named group.
+func (client Client) StartProcessGroupContext(ctx context.Context, name string, wait bool) (result bool, err error) {
+	params := makeParams(name, wait)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.startProcessGroup", params, &result))
+	})
+	return
+}
+
+// StopProcessGroupContext tells Supervisor to stop all running processes in the named group.
+func (client Client) StopProcessGroupContext(ctx context.Context, name string, wait bool) (result bool, err error) {
+	params := makeParams(name, wait)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.stopProcessGroup", params, &result))
+	})
+	return
+}
+
+// SendProcessStdinContext sends data to the stdin of a running process.
+func (client Client) SendProcessStdinContext(ctx context.Context, name string, chars string) (result bool, err error) {
+	params := makeParams(name, chars)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.sendProcessStdin", params, &result))
+	})
+	return
+}
+
+// SendRemoteCommEventContext sends an event to Supervisor processes listening to RemoteCommunicationEvents.
+func (client Client) SendRemoteCommEventContext(ctx context.Context, typeKey string, data string) (result bool, err error) {
+	params := makeParams(typeKey, data)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.sendRemoteCommEvent", params, &result))
+	})
+	return
+}
+
+// AddProcessGroupContext adds a configured process group to Supervisor.
+func (client Client) AddProcessGroupContext(ctx context.Context, name string) (result bool, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.addProcessGroup", name, &result))
+	})
+	return
+}
+
+// RemoveProcessGroupContext removes a configured process group from Supervisor.
+func (client Client) RemoveProcessGroupContext(ctx context.Context, name string) (result bool, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.removeProcessGroup", name, &result))
+	})
+	return
+}
+
+// ReadLogContext reads the Supervisor process log.
+func (client Client) ReadLogContext(ctx context.Context, offset int64, length int64) (log string, err error) {
+	params := makeParams(offset, length)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.readLog", params, &log))
+	})
+	return
+}
+
+// ReadProcessStdoutLogContext reads the stdout log for the named process.
+func (client Client) ReadProcessStdoutLogContext(ctx context.Context, name string, offset int64, length int64) (log string, err error) {
+	params := makeParams(name, offset, length)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.readProcessStdoutLog", params, &log))
+	})
+	return
+}
+
+// ReadProcessStderrLogContext reads the stderr log for the named process.
+func (client Client) ReadProcessStderrLogContext(ctx context.Context, name string, offset int64, length int64) (log string, err error) {
+	params := makeParams(name, offset, length)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.readProcessStderrLog", params, &log))
+	})
+	return
+}
+
+// TailProcessStdoutLogContext reads the stdout log for the named process.
+func (client Client) TailProcessStdoutLogContext(ctx context.Context, name string, offset int64, length int64) (tail *ProcessTail, err error) {
+	params := makeParams(name, offset, length)
+	result := make([]interface{}, 0, 3)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.tailProcessStdoutLog", params, &result))
+	})
+	if err == nil {
+		tail = newProcessTail(result)
+	}
+	return
+}
+
+// TailProcessStderrLogContext reads the stderr log for the named process.
+func (client Client) TailProcessStderrLogContext(ctx context.Context, name string, offset int64, length int64) (tail *ProcessTail, err error) {
+	params := makeParams(name, offset, length)
+	result := make([]interface{}, 0, 3)
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.tailProcessStderrLog", params, &result))
+	})
+	if err == nil {
+		tail = newProcessTail(result)
+	}
+	return
+}
+
+// ClearProcessLogsContext clears all logs for the named process.
+func (client Client) ClearProcessLogsContext(ctx context.Context, name string) (result bool, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.clearProcessLogs", name, &result))
+	})
+	return
+}
+
+// ClearAllProcessLogsContext clears all logs for all processes.
+func (client Client) ClearAllProcessLogsContext(ctx context.Context, name string) (result bool, err error) {
+	err = client.callContext(ctx, func() error {
+		return wrapFault(client.RpcClient.Call("supervisor.clearAllProcessLogs", name, &result))
+	})
+	return
+}
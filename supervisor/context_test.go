@@ -0,0 +1,81 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestCtxTransportCallDoesNotDeadlock guards against call() holding fieldMu
+// across fn(), which self-deadlocks as soon as fn() invokes RoundTrip on the
+// same goroutine (the normal synchronous path through xmlrpc.Client.Call).
+func TestCtxTransportCallDoesNotDeadlock(t *testing.T) {
+	var gotCtx context.Context
+	sawErr := errors.New("stub: no response")
+	transport := &ctxTransport{
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			gotCtx = req.Context()
+			return nil, sawErr
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- transport.call(ctx, func() error {
+			req, _ := http.NewRequest(http.MethodPost, "http://example.invalid", nil)
+			_, err := transport.RoundTrip(req)
+			return err
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, sawErr) {
+			t.Fatalf("call() returned %v, want %v", err, sawErr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ctxTransport.call deadlocked")
+	}
+
+	if gotCtx != ctx {
+		t.Fatalf("RoundTrip observed ctx %v, want %v", gotCtx, ctx)
+	}
+}
+
+// TestCtxTransportResetsCtxAfterCall ensures a later RoundTrip through the
+// same transport (e.g. a plain, non-Context method called on a Client built
+// by NewClientContext) doesn't inherit a finished call's, possibly
+// cancelled, context.
+func TestCtxTransportResetsCtxAfterCall(t *testing.T) {
+	var gotCtx context.Context
+	transport := &ctxTransport{
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			gotCtx = req.Context()
+			return nil, nil
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = transport.call(ctx, func() error { return nil })
+
+	req, _ := http.NewRequest(http.MethodPost, "http://example.invalid", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+
+	if err := gotCtx.Err(); err != nil {
+		t.Fatalf("RoundTrip reused the cancelled call context: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Package eventlistener implements Supervisor's event listener notification
+// protocol (http://supervisord.org/events.html#event-listener-notification-protocol),
+// the line-oriented stdin/stdout handshake supervisord uses to push
+// PROCESS_STATE, TICK and other events to a subscribed child process. It
+// complements the XML-RPC surface in the supervisor package, which only lets
+// a Go program poll supervisord rather than be notified by it.
+package eventlistener
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Header is the line Supervisor sends ahead of every event's payload.
+type Header struct {
+	Ver        string
+	Server     string
+	Serial     string
+	Pool       string
+	PoolSerial string
+	EventName  string
+	Len        int
+}
+
+func parseHeader(line string) (Header, error) {
+	fields := parseKeyValues(line)
+	length, err := strconv.Atoi(fields["len"])
+	if err != nil {
+		return Header{}, fmt.Errorf("eventlistener: invalid len in header %q: %w", line, err)
+	}
+	return Header{
+		Ver:        fields["ver"],
+		Server:     fields["server"],
+		Serial:     fields["serial"],
+		Pool:       fields["pool"],
+		PoolSerial: fields["poolserial"],
+		EventName:  fields["eventname"],
+		Len:        length,
+	}, nil
+}
+
+func parseKeyValues(s string) map[string]string {
+	fields := make(map[string]string)
+	for _, kv := range strings.Fields(s) {
+		if key, value, ok := strings.Cut(kv, ":"); ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// Payload is the raw body of an event, formatted by Supervisor as
+// space-separated key:value pairs. Use the ParseX method matching the
+// event's Header.EventName to decode it into a typed struct.
+type Payload []byte
+
+// ProcessStateEvent is the payload of every PROCESS_STATE_* event. Supervisor
+// does not include the process's exit status in this payload (only whether
+// the transition was expected); call Client.GetProcessInfo for that.
+type ProcessStateEvent struct {
+	ProcessName string
+	GroupName   string
+	FromState   string
+	PID         int64
+	Expected    bool
+}
+
+// ParseProcessStateEvent decodes a PROCESS_STATE_* payload. Fields that don't
+// apply to the specific transition (e.g. PID on PROCESS_STATE_STOPPED) are
+// left zero.
+func (p Payload) ParseProcessStateEvent() ProcessStateEvent {
+	fields := parseKeyValues(string(p))
+	pid, _ := strconv.ParseInt(fields["pid"], 10, 64)
+	return ProcessStateEvent{
+		ProcessName: fields["processname"],
+		GroupName:   fields["groupname"],
+		FromState:   fields["from_state"],
+		PID:         pid,
+		Expected:    fields["expected"] == "1",
+	}
+}
+
+// Result is a handler's verdict on an event, reported back to Supervisor as
+// the RESULT line of the protocol.
+type Result int
+
+const (
+	OK Result = iota
+	Fail
+)
+
+// Handler processes one event and returns whether Supervisor should consider
+// it handled.
+type Handler func(Header, Payload) Result
+
+// EventListener speaks Supervisor's event listener protocol over in/out,
+// dispatching each event to a Handler registered for its event type.
+type EventListener struct {
+	in       *bufio.Reader
+	out      io.Writer
+	handlers map[string]Handler
+}
+
+// New returns an EventListener that reads events from in and acknowledges
+// them on out. A supervisor-managed event listener process is invoked with
+// its stdin/stdout already wired to supervisord, so callers typically pass
+// os.Stdin and os.Stdout.
+func New(in io.Reader, out io.Writer) *EventListener {
+	return &EventListener{
+		in:       bufio.NewReader(in),
+		out:      out,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register calls handler for every event whose name matches or is prefixed
+// by eventType, e.g. registering "PROCESS_STATE" also receives
+// PROCESS_STATE_RUNNING, PROCESS_STATE_EXITED, and so on. Registering "*"
+// catches every event with no other matching handler.
+func (l *EventListener) Register(eventType string, handler Handler) {
+	l.handlers[eventType] = handler
+}
+
+// Run processes events until ctx is cancelled or reading from in fails (most
+// commonly because supervisord closed the pipe on shutdown). ctx is only
+// checked between events, since the underlying blocking read on in cannot be
+// interrupted once it has started.
+func (l *EventListener) Run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(l.out, "READY\n"); err != nil {
+			return err
+		}
+
+		line, err := l.in.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		header, err := parseHeader(strings.TrimRight(line, "\n"))
+		if err != nil {
+			return err
+		}
+
+		payload := make([]byte, header.Len)
+		if _, err := io.ReadFull(l.in, payload); err != nil {
+			return err
+		}
+
+		if err := writeResult(l.out, l.dispatch(header, Payload(payload))); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *EventListener) dispatch(header Header, payload Payload) Result {
+	if handler, ok := l.handlers[header.EventName]; ok {
+		return handler(header, payload)
+	}
+	for eventType, handler := range l.handlers {
+		if eventType != "*" && strings.HasPrefix(header.EventName, eventType) {
+			return handler(header, payload)
+		}
+	}
+	if handler, ok := l.handlers["*"]; ok {
+		return handler(header, payload)
+	}
+	return OK
+}
+
+func writeResult(out io.Writer, result Result) error {
+	body := "OK"
+	if result == Fail {
+		body = "FAIL"
+	}
+	_, err := fmt.Fprintf(out, "RESULT %d\n%s", len(body), body)
+	return err
+}
@@ -0,0 +1,131 @@
+// Package exporter adapts a supervisor.Client into a prometheus.Collector,
+// so a process tree managed by supervisord can be scraped like any other
+// Prometheus target.
+package exporter
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sflechie/go-supervisor/supervisor"
+)
+
+const namespace = "supervisor"
+
+// processStates lists every state name Supervisor's process state machine can
+// report (see http://supervisord.org/subprocess.html#process-states). Each is
+// exported as its own 0/1 time series on the state gauge, so "which state is
+// a process in" survives aggregation in PromQL without string matching.
+var processStates = []string{
+	"STOPPED",
+	"STARTING",
+	"RUNNING",
+	"BACKOFF",
+	"STOPPING",
+	"EXITED",
+	"FATAL",
+	"UNKNOWN",
+}
+
+// Exporter wraps a supervisor.Client and reports per-process state, exit
+// status, uptime and restart counts as Prometheus metrics.
+type Exporter struct {
+	client supervisor.Client
+
+	up         *prometheus.Desc
+	state      *prometheus.Desc
+	uptime     *prometheus.Desc
+	exitStatus *prometheus.Desc
+	restarts   *prometheus.Desc
+
+	mu            sync.Mutex
+	lastState     map[string]string
+	restartCounts map[string]float64
+}
+
+// New returns an Exporter that scrapes client on every Collect.
+func New(client supervisor.Client) *Exporter {
+	return &Exporter{
+		client: client,
+		up: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "up"),
+			"Whether the last scrape of Supervisor succeeded.",
+			nil, nil,
+		),
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "process", "state"),
+			"State of the process (1 for the current state, 0 for all others).",
+			[]string{"name", "group", "state"}, nil,
+		),
+		uptime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "process", "uptime_seconds"),
+			"Seconds since the process last entered the RUNNING state.",
+			[]string{"name", "group"}, nil,
+		),
+		exitStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "process", "exit_status"),
+			"Exit status of the process the last time it exited.",
+			[]string{"name", "group"}, nil,
+		),
+		restarts: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "process", "restarts_total"),
+			"Number of times the process has been observed restarting.",
+			[]string{"name", "group"}, nil,
+		),
+		lastState:     make(map[string]string),
+		restartCounts: make(map[string]float64),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.up
+	ch <- e.state
+	ch <- e.uptime
+	ch <- e.exitStatus
+	ch <- e.restarts
+}
+
+// Collect implements prometheus.Collector. A failed RPC reports supervisor_up
+// as 0 rather than panicking or dropping the scrape.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	infos, err := e.client.GetAllProcessInfo()
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(e.up, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(e.up, prometheus.GaugeValue, 1)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, info := range infos {
+		for _, state := range processStates {
+			value := 0.0
+			if state == info.StateName {
+				value = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(e.state, prometheus.GaugeValue, value, info.Name, info.Group, state)
+		}
+
+		uptime := 0.0
+		if info.StateName == "RUNNING" {
+			uptime = float64(info.Now - info.Start)
+		}
+		ch <- prometheus.MustNewConstMetric(e.uptime, prometheus.GaugeValue, uptime, info.Name, info.Group)
+		ch <- prometheus.MustNewConstMetric(e.exitStatus, prometheus.GaugeValue, float64(info.ExitStatus), info.Name, info.Group)
+
+		key := info.Group + "/" + info.Name
+		// A restart always re-enters STARTING, whether from a graceful stop
+		// (RUNNING -> STOPPING -> STOPPED -> STARTING) or a crash (RUNNING ->
+		// BACKOFF/EXITED -> STARTING); it never arrives there directly from
+		// RUNNING, so gate on the state observed just before this scrape
+		// rather than on RUNNING specifically.
+		if last, ok := e.lastState[key]; ok && last != "STARTING" && info.StateName == "STARTING" {
+			e.restartCounts[key]++
+		}
+		e.lastState[key] = info.StateName
+		ch <- prometheus.MustNewConstMetric(e.restarts, prometheus.CounterValue, e.restartCounts[key], info.Name, info.Group)
+	}
+}
@@ -0,0 +1,80 @@
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// Fault is a Supervisor XML-RPC fault, one of the stable codes documented at
+// http://supervisord.org/api.html. Every method wraps its underlying
+// client.RpcClient.Call with wrapFault, so callers can use
+// errors.Is(err, supervisor.ErrAlreadyStarted) instead of matching strings.
+type Fault struct {
+	Code int
+	Name string
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("supervisor: fault %d %s", f.Code, f.Name)
+}
+
+// Is reports whether target is a *Fault with the same Code, so errors.Is can
+// match a wrapped fault against one of the exported sentinels below.
+func (f *Fault) Is(target error) bool {
+	other, ok := target.(*Fault)
+	return ok && other.Code == f.Code
+}
+
+// Exported fault sentinels, usable with errors.Is.
+var (
+	ErrBadName             = &Fault{10, "BAD_NAME"}
+	ErrBadSignal           = &Fault{11, "BAD_SIGNAL"}
+	ErrNoFile              = &Fault{20, "NO_FILE"}
+	ErrFailed              = &Fault{30, "FAILED"}
+	ErrAbnormalTermination = &Fault{40, "ABNORMAL_TERMINATION"}
+	ErrSpawnError          = &Fault{50, "SPAWN_ERROR"}
+	ErrAlreadyStarted      = &Fault{60, "ALREADY_STARTED"}
+	ErrNotRunning          = &Fault{70, "NOT_RUNNING"}
+	ErrSuccess             = &Fault{80, "SUCCESS"}
+	ErrAlreadyAdded        = &Fault{90, "ALREADY_ADDED"}
+	ErrStillRunning        = &Fault{91, "STILL_RUNNING"}
+	ErrCantReread          = &Fault{92, "CANT_REREAD"}
+	ErrShutdownState       = &Fault{93, "SHUTDOWN_STATE"}
+)
+
+var faultsByCode = map[int]*Fault{
+	ErrBadName.Code:             ErrBadName,
+	ErrBadSignal.Code:           ErrBadSignal,
+	ErrNoFile.Code:              ErrNoFile,
+	ErrFailed.Code:              ErrFailed,
+	ErrAbnormalTermination.Code: ErrAbnormalTermination,
+	ErrSpawnError.Code:          ErrSpawnError,
+	ErrAlreadyStarted.Code:      ErrAlreadyStarted,
+	ErrNotRunning.Code:          ErrNotRunning,
+	ErrSuccess.Code:             ErrSuccess,
+	ErrAlreadyAdded.Code:        ErrAlreadyAdded,
+	ErrStillRunning.Code:        ErrStillRunning,
+	ErrCantReread.Code:          ErrCantReread,
+	ErrShutdownState.Code:       ErrShutdownState,
+}
+
+// wrapFault replaces err with the matching *Fault sentinel when err is a
+// Supervisor XML-RPC fault. kolo/xmlrpc surfaces a server fault as an
+// xmlrpc.FaultError carrying the faultCode/faultString the XML-RPC response
+// sent, so the fault is identified by Code rather than by scanning
+// faultString text (whose format is not part of any contract).
+func wrapFault(err error) error {
+	if err == nil {
+		return nil
+	}
+	var faultErr xmlrpc.FaultError
+	if !errors.As(err, &faultErr) {
+		return err
+	}
+	if fault, ok := faultsByCode[faultErr.Code]; ok {
+		return &Fault{Code: fault.Code, Name: fault.Name}
+	}
+	return &Fault{Code: faultErr.Code, Name: faultErr.String}
+}
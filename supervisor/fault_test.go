@@ -0,0 +1,39 @@
+package supervisor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kolo/xmlrpc"
+)
+
+func TestWrapFaultMapsKnownCode(t *testing.T) {
+	err := wrapFault(xmlrpc.FaultError{Code: 60, String: "ALREADY_STARTED: already started"})
+	if !errors.Is(err, ErrAlreadyStarted) {
+		t.Fatalf("wrapFault(60) = %v, want errors.Is match for ErrAlreadyStarted", err)
+	}
+}
+
+func TestWrapFaultPreservesUnknownCode(t *testing.T) {
+	err := wrapFault(xmlrpc.FaultError{Code: 999, String: "SOMETHING_NEW: detail"})
+	var fault *Fault
+	if !errors.As(err, &fault) {
+		t.Fatalf("wrapFault(999) = %v, want a *Fault", err)
+	}
+	if fault.Code != 999 {
+		t.Fatalf("fault.Code = %d, want 999", fault.Code)
+	}
+}
+
+func TestWrapFaultPassesThroughNonFaults(t *testing.T) {
+	plain := errors.New("connection refused")
+	if got := wrapFault(plain); got != plain {
+		t.Fatalf("wrapFault(%v) = %v, want it unchanged", plain, got)
+	}
+}
+
+func TestWrapFaultNil(t *testing.T) {
+	if wrapFault(nil) != nil {
+		t.Fatal("wrapFault(nil) should be nil")
+	}
+}
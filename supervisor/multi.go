@@ -0,0 +1,289 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MultiClient fans a call out to many named supervisord endpoints
+// concurrently, so operators running supervisord on dozens of hosts can use
+// a single handle instead of looping over their own Client slice.
+type MultiClient struct {
+	clients map[string]Client
+
+	// Concurrency bounds how many hosts are contacted at once. Zero (the
+	// default) means one goroutine per host.
+	Concurrency int
+
+	// Timeout bounds each host's call via context.WithTimeout, in addition to
+	// any deadline already on the ctx passed to a method. Zero means no
+	// additional timeout is applied.
+	Timeout time.Duration
+
+	// StopOnFirstError cancels every still-running host call as soon as one
+	// host errors, instead of waiting for all of them to finish.
+	StopOnFirstError bool
+}
+
+// NewMultiClient connects to every named endpoint in endpoints (host name ->
+// Supervisor XML-RPC URL, each possibly unix:// or http://) and returns a
+// MultiClient wrapping them. It returns the first connection error it
+// encounters, including the offending host in the message.
+//
+// Hosts are connected via NewClientContext rather than NewClient: multiCall
+// always calls a host's *Context method, and that only honors the per-call
+// ctx (and therefore Timeout/StopOnFirstError) when the Client was built
+// with a ctxTransport to bind it to.
+func NewMultiClient(endpoints map[string]string) (*MultiClient, error) {
+	clients := make(map[string]Client, len(endpoints))
+	for host, url := range endpoints {
+		client, err := NewClientContext(context.Background(), url)
+		if err != nil {
+			return nil, fmt.Errorf("supervisor: connecting to host %q (%s): %w", host, url, err)
+		}
+		clients[host] = client
+	}
+	return &MultiClient{clients: clients}, nil
+}
+
+// Close closes every underlying Client, collecting per-host errors the same
+// way the fan-out methods do.
+func (m *MultiClient) Close() map[string]error {
+	errs := make(map[string]error)
+	for host, client := range m.clients {
+		if err := client.Close(); err != nil {
+			errs[host] = err
+		}
+	}
+	return errs
+}
+
+func (m *MultiClient) concurrency() int {
+	if m.Concurrency > 0 {
+		return m.Concurrency
+	}
+	return len(m.clients)
+}
+
+// multiCall runs fn against every host in m concurrently, honoring
+// Concurrency, Timeout and StopOnFirstError, and collects each host's result
+// or error into its own map.
+func multiCall[T any](m *MultiClient, ctx context.Context, fn func(context.Context, Client) (T, error)) (map[string]T, map[string]error) {
+	results := make(map[string]T, len(m.clients))
+	errs := make(map[string]error)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, m.concurrency())
+
+	for host, client := range m.clients {
+		host, client := host, client
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			hostCtx := ctx
+			if m.Timeout > 0 {
+				var hostCancel context.CancelFunc
+				hostCtx, hostCancel = context.WithTimeout(ctx, m.Timeout)
+				defer hostCancel()
+			}
+
+			result, err := fn(hostCtx, client)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[host] = err
+				if m.StopOnFirstError {
+					cancel()
+				}
+				return
+			}
+			results[host] = result
+		}()
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
+// GetSupervisorVersion returns the Supervisor version each host connects to.
+func (m *MultiClient) GetSupervisorVersion(ctx context.Context) (map[string]string, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (string, error) {
+		return c.GetSupervisorVersionContext(ctx)
+	})
+}
+
+// GetIdentification returns each host's Supervisor ID string.
+func (m *MultiClient) GetIdentification(ctx context.Context) (map[string]string, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (string, error) {
+		return c.GetIdentificationContext(ctx)
+	})
+}
+
+// GetState returns each host's Supervisor process state.
+func (m *MultiClient) GetState(ctx context.Context) (map[string]*SupervisorState, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (*SupervisorState, error) {
+		return c.GetStateContext(ctx)
+	})
+}
+
+// GetPID returns each host's Supervisor process PID.
+func (m *MultiClient) GetPID(ctx context.Context) (map[string]int64, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (int64, error) {
+		return c.GetPIDContext(ctx)
+	})
+}
+
+// ClearLog clears the Supervisor process log on every host.
+func (m *MultiClient) ClearLog(ctx context.Context) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.ClearLogContext(ctx)
+	})
+}
+
+// Shutdown shuts down the Supervisor process on every host.
+func (m *MultiClient) Shutdown(ctx context.Context) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.ShutdownContext(ctx)
+	})
+}
+
+// Restart restarts the Supervisor process on every host.
+func (m *MultiClient) Restart(ctx context.Context) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.RestartContext(ctx)
+	})
+}
+
+// ReloadConfig reloads the Supervisor configuration on every host.
+func (m *MultiClient) ReloadConfig(ctx context.Context) (map[string]ReloadInfo, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (ReloadInfo, error) {
+		return c.ReloadConfigContext(ctx)
+	})
+}
+
+// GetProcessInfo retrieves information for the named process from every host.
+func (m *MultiClient) GetProcessInfo(ctx context.Context, name string) (map[string]ProcessInfo, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (ProcessInfo, error) {
+		return c.GetProcessInfoContext(ctx, name)
+	})
+}
+
+// ProcessInfoWithHost is a ProcessInfo annotated with the host that reported it.
+type ProcessInfoWithHost struct {
+	ProcessInfo
+	Host string
+}
+
+// GetAllProcessInfo retrieves process info from every host and flattens the
+// per-host results into one slice, each entry labelled with its Host.
+func (m *MultiClient) GetAllProcessInfo(ctx context.Context) ([]ProcessInfoWithHost, map[string]error) {
+	results, errs := multiCall(m, ctx, func(ctx context.Context, c Client) ([]ProcessInfo, error) {
+		return c.GetAllProcessInfoContext(ctx)
+	})
+
+	var infos []ProcessInfoWithHost
+	for host, hostInfos := range results {
+		for _, info := range hostInfos {
+			infos = append(infos, ProcessInfoWithHost{ProcessInfo: info, Host: host})
+		}
+	}
+	return infos, errs
+}
+
+// StartProcess tells Supervisor on every host to start the named process.
+func (m *MultiClient) StartProcess(ctx context.Context, name string, wait bool) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.StartProcessContext(ctx, name, wait)
+	})
+}
+
+// StopProcess tells Supervisor on every host to stop the named process.
+func (m *MultiClient) StopProcess(ctx context.Context, name string, wait bool) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.StopProcessContext(ctx, name, wait)
+	})
+}
+
+// StartAllProcesses tells Supervisor on every host to start all stopped processes.
+func (m *MultiClient) StartAllProcesses(ctx context.Context, wait bool) (map[string][]ProcessStatus, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) ([]ProcessStatus, error) {
+		return c.StartAllProcessesContext(ctx, wait)
+	})
+}
+
+// StopAllProcesses tells Supervisor on every host to stop all running processes.
+func (m *MultiClient) StopAllProcesses(ctx context.Context, wait bool) (map[string][]ProcessStatus, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) ([]ProcessStatus, error) {
+		return c.StopAllProcessesContext(ctx, wait)
+	})
+}
+
+// StartProcessGroup tells Supervisor on every host to start all stopped
+// processes in the named group.
+func (m *MultiClient) StartProcessGroup(ctx context.Context, group string, wait bool) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.StartProcessGroupContext(ctx, group, wait)
+	})
+}
+
+// StopProcessGroup tells Supervisor on every host to stop all running
+// processes in the named group.
+func (m *MultiClient) StopProcessGroup(ctx context.Context, group string, wait bool) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.StopProcessGroupContext(ctx, group, wait)
+	})
+}
+
+// SendProcessStdin sends data to the stdin of a running process on every host.
+func (m *MultiClient) SendProcessStdin(ctx context.Context, name string, chars string) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.SendProcessStdinContext(ctx, name, chars)
+	})
+}
+
+// SendRemoteCommEvent sends an event to every host's Supervisor processes
+// listening to RemoteCommunicationEvents.
+func (m *MultiClient) SendRemoteCommEvent(ctx context.Context, typeKey string, data string) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.SendRemoteCommEventContext(ctx, typeKey, data)
+	})
+}
+
+// AddProcessGroup adds a configured process group to Supervisor on every host.
+func (m *MultiClient) AddProcessGroup(ctx context.Context, name string) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.AddProcessGroupContext(ctx, name)
+	})
+}
+
+// RemoveProcessGroup removes a configured process group from Supervisor on every host.
+func (m *MultiClient) RemoveProcessGroup(ctx context.Context, name string) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.RemoveProcessGroupContext(ctx, name)
+	})
+}
+
+// ClearProcessLogs clears all logs for the named process on every host.
+func (m *MultiClient) ClearProcessLogs(ctx context.Context, name string) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.ClearProcessLogsContext(ctx, name)
+	})
+}
+
+// ClearAllProcessLogs clears all logs for all processes on every host.
+func (m *MultiClient) ClearAllProcessLogs(ctx context.Context, name string) (map[string]bool, map[string]error) {
+	return multiCall(m, ctx, func(ctx context.Context, c Client) (bool, error) {
+		return c.ClearAllProcessLogsContext(ctx, name)
+	})
+}
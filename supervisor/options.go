@@ -0,0 +1,141 @@
+package supervisor
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// clientConfig accumulates the effect of the Options passed to NewClient or
+// NewClientContext.
+type clientConfig struct {
+	transport       http.RoundTripper
+	httpClient      *http.Client
+	tlsConfig       *tls.Config
+	unixSocket      string
+	basicAuthSet    bool
+	basicAuthUser   string
+	basicAuthPass   string
+	checkAPIVersion bool
+}
+
+// Option configures a Client built by NewClient or NewClientContext.
+type Option func(*clientConfig)
+
+// WithBasicAuth sets the username and password sent as HTTP Basic Auth on
+// every request, matching a supervisord [inet_http_server] section that sets
+// username/password.
+func WithBasicAuth(user, pass string) Option {
+	return func(c *clientConfig) {
+		c.basicAuthSet = true
+		c.basicAuthUser = user
+		c.basicAuthPass = pass
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to dial the Supervisor
+// endpoint, for connecting through a TLS-terminating proxy. It has no effect
+// if WithTransport or WithHTTPClient (with its own Transport) is also given.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *clientConfig) {
+		c.tlsConfig = tlsConfig
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used for outgoing RPC requests.
+// Its Transport, if set, is used as the base RoundTripper in place of the
+// default one; a WithTransport, or the socket dialer auto-detected from a
+// unix:// url, takes precedence over it. Its Timeout is not honored, since
+// the underlying xmlrpc.Client issues requests without one.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *clientConfig) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport overrides the http.RoundTripper used for outgoing requests,
+// taking precedence over WithHTTPClient, WithTLSConfig and unix:// socket
+// auto-detection.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *clientConfig) {
+		c.transport = transport
+	}
+}
+
+// WithUnixSocket dials sock instead of the network for every outgoing
+// request. Use it when url doesn't follow the "unix://path/to/x.sock"
+// convention resolveTransport relies on for auto-detection (no unix://
+// scheme, or a socket path without a .sock suffix). It has no effect if
+// WithTransport, WithHTTPClient or WithTLSConfig is also given.
+func WithUnixSocket(sock string) Option {
+	return func(c *clientConfig) {
+		c.unixSocket = sock
+	}
+}
+
+// WithAPIVersionCheck controls whether NewClient/NewClientContext reject a
+// server whose supervisor.getAPIVersion doesn't equal apiVersion. It
+// defaults to true; disable it to connect to a server that reports a
+// mismatched API version but is otherwise known to be compatible.
+func WithAPIVersionCheck(check bool) Option {
+	return func(c *clientConfig) {
+		c.checkAPIVersion = check
+	}
+}
+
+// newClientConfig resolves url (rewriting and stripping unix:// the same way
+// resolveTransport always has) and applies opts on top of that default, so a
+// WithTransport in opts overrides the unix socket dialer rather than
+// fighting it. A WithUnixSocket is applied afterwards as a second way to
+// reach the same dialer, for callers whose url doesn't fit the unix://
+// convention resolveTransport looks for.
+func newClientConfig(url string, opts []Option) (string, *clientConfig) {
+	resolvedURL, autoTransport := resolveTransport(url)
+
+	cfg := &clientConfig{
+		transport:       autoTransport,
+		checkAPIVersion: true,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.unixSocket != "" && cfg.transport == autoTransport && cfg.httpClient == nil && cfg.tlsConfig == nil {
+		resolvedURL = "http://localhost:80"
+		cfg.transport = &http.Transport{Dial: dialer(cfg.unixSocket)}
+	}
+
+	return resolvedURL, cfg
+}
+
+// roundTripper resolves the configured options into the single RoundTripper
+// xmlrpc.NewClient expects, applying basic auth last so it wraps whatever
+// transport the other options selected.
+func (c *clientConfig) roundTripper() http.RoundTripper {
+	transport := c.transport
+	if transport == nil && c.httpClient != nil && c.httpClient.Transport != nil {
+		transport = c.httpClient.Transport
+	}
+	if transport == nil && c.tlsConfig != nil {
+		transport = &http.Transport{TLSClientConfig: c.tlsConfig}
+	}
+	if !c.basicAuthSet {
+		return transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &basicAuthTransport{base: transport, user: c.basicAuthUser, pass: c.basicAuthPass}
+}
+
+// basicAuthTransport adds HTTP Basic Auth credentials to every request
+// before delegating to base.
+type basicAuthTransport struct {
+	base       http.RoundTripper
+	user, pass string
+}
+
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.user, t.pass)
+	return t.base.RoundTrip(req)
+}
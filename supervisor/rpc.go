@@ -163,6 +163,12 @@ func (info ReloadInfo) String() string {
 type Client struct {
 	RpcClient  *xmlrpc.Client
 	ApiVersion string
+
+	// ctxTransport is non-nil when the Client was built by NewClientContext,
+	// and lets the *Context methods bind an in-flight call to its caller's
+	// context so a cancellation aborts the HTTP POST rather than waiting for
+	// the unmodified kolo/xmlrpc Call to return on its own.
+	ctxTransport *ctxTransport
 }
 
 func dialer(sock string) func(proto, addr string) (net.Conn, error) {
@@ -171,22 +177,33 @@ func dialer(sock string) func(proto, addr string) (net.Conn, error) {
 	}
 }
 
-// NewClient creates a new supervisor RPC client.
-func NewClient(url string) (client Client, err error) {
-	var rpc *xmlrpc.Client
+// resolveTransport rewrites a "unix://path/to/x.sock" URL (with an optional
+// path after .sock) into the fake HTTP URL understood by xmlrpc.NewClient and
+// returns a Transport that dials the socket instead of the network. A unix://
+// URL without a .sock segment is returned unchanged, with a Transport that
+// dials the whole path. Non-unix URLs are returned unchanged with a nil
+// transport, leaving xmlrpc.NewClient to pick its own default.
+func resolveTransport(url string) (string, http.RoundTripper) {
+	if !strings.HasPrefix(url, "unix://") {
+		return url, nil
+	}
+	var sock = strings.TrimPrefix(url, "unix://")
+	if index := strings.Index(sock, ".sock"); index > 0 {
+		url = "http://localhost:80" + sock[index+5:] //fake
+		sock = sock[:index+5]
+	}
+	return url, &http.Transport{Dial: dialer(sock)}
+}
 
-	var transport http.RoundTripper
+// NewClient creates a new supervisor RPC client. By default it assumes plain
+// HTTP with no auth (or, for a "unix://" URL, a Unix domain socket); use the
+// With* Options to add HTTP Basic Auth, TLS, or a custom Transport.
+func NewClient(url string, opts ...Option) (client Client, err error) {
+	var rpc *xmlrpc.Client
 
-	if strings.HasPrefix(url, "unix://") {
-		var sock = strings.TrimPrefix(url, "unix://")
-		if index := strings.Index(sock, ".sock"); index > 0 {
-			url = "http://localhost:80" + sock[index+5:] //fake
-			sock = sock[:index+5]
-		}
-		transport = &http.Transport{Dial: dialer(sock)}
-	}
+	resolvedURL, cfg := newClientConfig(url, opts)
 
-	if rpc, err = xmlrpc.NewClient(url, transport); err != nil {
+	if rpc, err = xmlrpc.NewClient(resolvedURL, cfg.roundTripper()); err != nil {
 		return
 	}
 
@@ -194,11 +211,11 @@ func NewClient(url string) (client Client, err error) {
 	if err = rpc.Call("supervisor.getAPIVersion", nil, &version); err != nil {
 		return
 	}
-	if version != apiVersion {
+	if cfg.checkAPIVersion && version != apiVersion {
 		err = errors.New(fmt.Sprintf("want Supervisor API version %s, got %s instead", apiVersion, version))
 		return
 	}
-	client = Client{rpc, version}
+	client = Client{RpcClient: rpc, ApiVersion: version}
 	return
 }
 
@@ -209,20 +226,20 @@ func (client Client) Close() error {
 
 // GetSupervisorVersion returns the Supervisor version we connect to.
 func (client Client) GetSupervisorVersion() (version string, err error) {
-	err = client.RpcClient.Call("supervisor.getSupervisorVersion", nil, &version)
+	err = wrapFault(client.RpcClient.Call("supervisor.getSupervisorVersion", nil, &version))
 	return
 }
 
 // GetIdentification returns the Supervisor ID string.
 func (client Client) GetIdentification() (id string, err error) {
-	err = client.RpcClient.Call("supervisor.getIdentification", nil, &id)
+	err = wrapFault(client.RpcClient.Call("supervisor.getIdentification", nil, &id))
 	return
 }
 
 // GetState returns the Supervisor process state.
 func (client Client) GetState() (state *SupervisorState, err error) {
 	result := make(map[string]interface{})
-	if err = client.RpcClient.Call("supervisor.getState", nil, &result); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.getState", nil, &result)); err == nil {
 		state = newSupervisorState(result)
 	}
 	return
@@ -230,31 +247,31 @@ func (client Client) GetState() (state *SupervisorState, err error) {
 
 // GetPID returns the Supervisor process PID.
 func (client Client) GetPID() (pid int64, err error) {
-	err = client.RpcClient.Call("supervisor.getPID", nil, &pid)
+	err = wrapFault(client.RpcClient.Call("supervisor.getPID", nil, &pid))
 	return
 }
 
 // ClearLog clears the Supervisor process log.
 func (client Client) ClearLog() (result bool, err error) {
-	err = client.RpcClient.Call("supervisor.clearLog", nil, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.clearLog", nil, &result))
 	return
 }
 
 // Shutdown shuts down the Supervisor process.
 func (client Client) Shutdown() (result bool, err error) {
-	err = client.RpcClient.Call("supervisor.shutdown", nil, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.shutdown", nil, &result))
 	return
 }
 
 // Restart restarts the Supervisor process.
 func (client Client) Restart() (result bool, err error) {
-	err = client.RpcClient.Call("supervisor.restart", nil, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.restart", nil, &result))
 	return
 }
 
 func (client Client) ReloadConfig() (info ReloadInfo, err error) {
 	var results []interface{}
-	if err = client.RpcClient.Call("supervisor.reloadConfig", nil, &results); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.reloadConfig", nil, &results)); err == nil {
 		info = newReloadInfo(results[0].([]interface{}))
 	}
 	return
@@ -263,7 +280,7 @@ func (client Client) ReloadConfig() (info ReloadInfo, err error) {
 // GetProcessInfo retrieves information for a particular Supervisor process.
 func (client Client) GetProcessInfo(name string) (info ProcessInfo, err error) {
 	result := make(map[string]interface{})
-	if err = client.RpcClient.Call("supervisor.getProcessInfo", name, &result); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.getProcessInfo", name, &result)); err == nil {
 		info = newProcessInfo(result)
 	}
 	return
@@ -272,7 +289,7 @@ func (client Client) GetProcessInfo(name string) (info ProcessInfo, err error) {
 // GetAllProcessInfo retrieves information for all Supervisor processes.
 func (client Client) GetAllProcessInfo() (info []ProcessInfo, err error) {
 	var results []interface{}
-	if err = client.RpcClient.Call("supervisor.getAllProcessInfo", nil, &results); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.getAllProcessInfo", nil, &results)); err == nil {
 		info = make([]ProcessInfo, len(results))
 		for i, result := range results {
 			info[i] = newProcessInfo(result.(map[string]interface{}))
@@ -284,21 +301,21 @@ func (client Client) GetAllProcessInfo() (info []ProcessInfo, err error) {
 // StartProcess tells Supervisor to start the named process.
 func (client Client) StartProcess(name string, wait bool) (result bool, err error) {
 	params := makeParams(name, wait)
-	err = client.RpcClient.Call("supervisor.startProcess", params, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.startProcess", params, &result))
 	return
 }
 
 // StopProcess tells Supervisor to stop the named process.
 func (client Client) StopProcess(name string, wait bool) (result bool, err error) {
 	params := makeParams(name, wait)
-	err = client.RpcClient.Call("supervisor.stopProcess", params, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.stopProcess", params, &result))
 	return
 }
 
 // StartAllProcesses tells Supervisor to start all stopped processes.
 func (client Client) StartAllProcesses(wait bool) (info []ProcessStatus, err error) {
 	var results []interface{}
-	if err = client.RpcClient.Call("supervisor.startAllProcesses", wait, &results); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.startAllProcesses", wait, &results)); err == nil {
 		info = make([]ProcessStatus, len(results))
 		for i, result := range results {
 			info[i] = newProcessStatus(result.(map[string]interface{}))
@@ -310,7 +327,7 @@ func (client Client) StartAllProcesses(wait bool) (info []ProcessStatus, err err
 // StopAllProcesses teslls Supervisor to stop all running processes.
 func (client Client) StopAllProcesses(wait bool) (info []ProcessStatus, err error) {
 	var results []interface{}
-	if err = client.RpcClient.Call("supervisor.stopAllProcesses", wait, &results); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.stopAllProcesses", wait, &results)); err == nil {
 		info = make([]ProcessStatus, len(results))
 		for i, result := range results {
 			info[i] = newProcessStatus(result.(map[string]interface{}))
@@ -322,61 +339,61 @@ func (client Client) StopAllProcesses(wait bool) (info []ProcessStatus, err erro
 // StartProcessGroup tells Supervisor to start all stopped processes in the named group.
 func (client Client) StartProcessGroup(name string, wait bool) (result bool, err error) {
 	params := makeParams(name, wait)
-	err = client.RpcClient.Call("supervisor.startProcessGroup", params, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.startProcessGroup", params, &result))
 	return
 }
 
 // StopProcessGroup tells Supervisor to start all stopped processes in the named group.
 func (client Client) StopProcessGroup(name string, wait bool) (result bool, err error) {
 	params := makeParams(name, wait)
-	err = client.RpcClient.Call("supervisor.stopProcessGroup", params, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.stopProcessGroup", params, &result))
 	return
 }
 
 // SendProcessStdin send data to the stdin of a running process.
 func (client Client) SendProcessStdin(name string, chars string) (result bool, err error) {
 	params := makeParams(name, chars)
-	err = client.RpcClient.Call("supervisor.sendProcessStdin", params, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.sendProcessStdin", params, &result))
 	return
 }
 
 // SendRemoteCommEvent sends an event to Supervisor processes listening to RemoveCommunicationEvents..
 func (client Client) SendRemoteCommEvent(typeKey string, data string) (result bool, err error) {
 	params := makeParams(typeKey, data)
-	err = client.RpcClient.Call("supervisor.sendRemoteCommEvent", params, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.sendRemoteCommEvent", params, &result))
 	return
 }
 
 // AddProcessGroup adds a configured process group to Supervisor.
 func (client Client) AddProcessGroup(name string) (result bool, err error) {
-	err = client.RpcClient.Call("supervisor.addProcessGroup", name, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.addProcessGroup", name, &result))
 	return
 }
 
 // RemoveProcessGroup removes a configured process group from Supervisor.
 func (client Client) RemoveProcessGroup(name string) (result bool, err error) {
-	err = client.RpcClient.Call("supervisor.removeProcessGroup", name, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.removeProcessGroup", name, &result))
 	return
 }
 
 // ReadLog reads the Supervisor process log.
 func (client Client) ReadLog(offset int64, length int64) (log string, err error) {
 	params := makeParams(offset, length)
-	err = client.RpcClient.Call("supervisor.readLog", params, &log)
+	err = wrapFault(client.RpcClient.Call("supervisor.readLog", params, &log))
 	return
 }
 
 // ReadProcessStdoutLog reads the stdout log for the named process.
 func (client Client) ReadProcessStdoutLog(name string, offset int64, length int64) (log string, err error) {
 	params := makeParams(name, offset, length)
-	err = client.RpcClient.Call("supervisor.readProcessStdoutLog", params, &log)
+	err = wrapFault(client.RpcClient.Call("supervisor.readProcessStdoutLog", params, &log))
 	return
 }
 
 // ReadProcessStderrLog reads the stderr log for the named process.
 func (client Client) ReadProcessStderrLog(name string, offset int64, length int64) (log string, err error) {
 	params := makeParams(name, offset, length)
-	err = client.RpcClient.Call("supervisor.readProcessStderrLog", params, &log)
+	err = wrapFault(client.RpcClient.Call("supervisor.readProcessStderrLog", params, &log))
 	return
 }
 
@@ -384,7 +401,7 @@ func (client Client) ReadProcessStderrLog(name string, offset int64, length int6
 func (client Client) TailProcessStdoutLog(name string, offset int64, length int64) (tail *ProcessTail, err error) {
 	params := makeParams(name, offset, length)
 	result := make([]interface{}, 0, 3)
-	if err = client.RpcClient.Call("supervisor.tailProcessStdoutLog", params, &result); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.tailProcessStdoutLog", params, &result)); err == nil {
 		tail = newProcessTail(result)
 	}
 	return
@@ -394,7 +411,7 @@ func (client Client) TailProcessStdoutLog(name string, offset int64, length int6
 func (client Client) TailProcessStderrLog(name string, offset int64, length int64) (tail *ProcessTail, err error) {
 	params := makeParams(name, offset, length)
 	result := make([]interface{}, 0, 3)
-	if err = client.RpcClient.Call("supervisor.tailProcessStderrLog", params, &result); err == nil {
+	if err = wrapFault(client.RpcClient.Call("supervisor.tailProcessStderrLog", params, &result)); err == nil {
 		tail = newProcessTail(result)
 	}
 	return
@@ -402,12 +419,12 @@ func (client Client) TailProcessStderrLog(name string, offset int64, length int6
 
 // ClearProcessLogs clears all logs for the named process.
 func (client Client) ClearProcessLogs(name string) (result bool, err error) {
-	err = client.RpcClient.Call("supervisor.clearProcessLogs", name, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.clearProcessLogs", name, &result))
 	return
 }
 
 // ClearAllProcessLogs clears all logs all processes.
 func (client Client) ClearAllProcessLogs(name string) (result bool, err error) {
-	err = client.RpcClient.Call("supervisor.clearAllProcessLogs", name, &result)
+	err = wrapFault(client.RpcClient.Call("supervisor.clearAllProcessLogs", name, &result))
 	return
 }
@@ -0,0 +1,154 @@
+package supervisor
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	streamChunkSize       = 4096
+	streamIdleBackoffStep = 250 * time.Millisecond
+	streamIdleBackoffMax  = 2 * time.Second
+	streamEventsInterval  = time.Second
+)
+
+// LogChunk is one piece of output read from a streamed process log, or an
+// Overflow sentinel marking that Supervisor's log ring buffer wrapped and
+// some output between the last chunk and this one was lost.
+type LogChunk struct {
+	Data     string
+	Overflow bool
+}
+
+// StreamProcessStdout tails the named process's stdout from the current end
+// of the log, emitting chunks as they're written. It polls
+// TailProcessStdoutLog internally, tracking the offset cursor and backing off
+// while the log is idle, and closes the returned channel when ctx is
+// cancelled, the tail fails, or the process leaves the RUNNING state.
+func (client Client) StreamProcessStdout(ctx context.Context, name string) (<-chan LogChunk, error) {
+	return client.streamLog(ctx, name, client.TailProcessStdoutLogContext)
+}
+
+// StreamProcessStderr tails the named process's stderr; see StreamProcessStdout.
+func (client Client) StreamProcessStderr(ctx context.Context, name string) (<-chan LogChunk, error) {
+	return client.streamLog(ctx, name, client.TailProcessStderrLogContext)
+}
+
+func (client Client) streamLog(ctx context.Context, name string, tail func(context.Context, string, int64, int64) (*ProcessTail, error)) (<-chan LogChunk, error) {
+	start, err := tail(ctx, name, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	offset := start.Offset
+
+	ch := make(chan LogChunk)
+	go func() {
+		defer close(ch)
+		backoff := streamIdleBackoffStep
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			chunk, err := tail(ctx, name, offset, streamChunkSize)
+			if err != nil {
+				return
+			}
+
+			if chunk.Overflow {
+				select {
+				case ch <- LogChunk{Overflow: true}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			// chunk.Offset is the file size Supervisor observed at this read,
+			// i.e. the position one past the last byte of chunk.Log, so it is
+			// the single source of truth for the next read position: adding
+			// len(chunk.Log) on top of it would double-count those bytes and
+			// skip the next chunk of that length once the log grows further.
+			offset = chunk.Offset
+			if chunk.Log != "" {
+				backoff = streamIdleBackoffStep
+				select {
+				case ch <- LogChunk{Data: chunk.Log}:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+
+			info, err := client.GetProcessInfoContext(ctx, name)
+			if err != nil || info.StateName != "RUNNING" {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff < streamIdleBackoffMax {
+				backoff += streamIdleBackoffStep
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// Event reports a process transitioning between Supervisor states, as
+// observed by StreamEvents.
+type Event struct {
+	ProcessName string
+	GroupName   string
+	FromState   string
+	ToState     string
+}
+
+// StreamEvents multiplexes state-change notifications for every process
+// Supervisor manages, by polling GetAllProcessInfo and diffing state names,
+// so a caller can subscribe once instead of polling itself. The returned
+// channel closes when ctx is cancelled or a poll fails.
+func (client Client) StreamEvents(ctx context.Context) (<-chan Event, error) {
+	infos, err := client.GetAllProcessInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastState := make(map[string]string, len(infos))
+	for _, info := range infos {
+		lastState[info.Group+"/"+info.Name] = info.StateName
+	}
+
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(streamEventsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			infos, err := client.GetAllProcessInfoContext(ctx)
+			if err != nil {
+				return
+			}
+			for _, info := range infos {
+				key := info.Group + "/" + info.Name
+				if from, ok := lastState[key]; ok && from != info.StateName {
+					select {
+					case ch <- Event{ProcessName: info.Name, GroupName: info.Group, FromState: from, ToState: info.StateName}:
+					case <-ctx.Done():
+						return
+					}
+				}
+				lastState[key] = info.StateName
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,100 @@
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTail simulates the tailProcessStdoutLog/tailProcessStderrLog RPC: Log is
+// the slice of content starting at offset (bounded by length), and Offset is
+// the file size as of this read — one past the last byte of Log, matching
+// what supervisord actually returns. Each polling call (length != 0) blocks
+// until the test calls allow(), so a test can grow the content and release
+// exactly one poll at a time instead of racing streamLog's background loop.
+type fakeTail struct {
+	mu      sync.Mutex
+	content string
+	proceed chan struct{}
+}
+
+func newFakeTail(initial string) *fakeTail {
+	return &fakeTail{content: initial, proceed: make(chan struct{})}
+}
+
+func (f *fakeTail) grow(s string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.content += s
+}
+
+func (f *fakeTail) allow() { f.proceed <- struct{}{} }
+
+func (f *fakeTail) tail(ctx context.Context, _ string, offset, length int64) (*ProcessTail, error) {
+	if length != 0 {
+		select {
+		case <-f.proceed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if length == 0 {
+		return &ProcessTail{Offset: int64(len(f.content))}, nil
+	}
+	if offset > int64(len(f.content)) {
+		offset = int64(len(f.content))
+	}
+	chunk := f.content[offset:]
+	if int64(len(chunk)) > length {
+		chunk = chunk[:length]
+	}
+	return &ProcessTail{Log: chunk, Offset: offset + int64(len(chunk))}, nil
+}
+
+// TestStreamLogDoesNotSkipBytesOnGrowth guards against the cursor being
+// advanced past what was actually read: if streamLog ever sets offset ahead
+// of chunk.Offset, the bytes appended between this poll and the next are
+// silently skipped instead of delivered.
+func TestStreamLogDoesNotSkipBytesOnGrowth(t *testing.T) {
+	fake := newFakeTail("AAAA")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var client Client
+	ch, err := client.streamLog(ctx, "proc", fake.tail)
+	if err != nil {
+		t.Fatalf("streamLog: %v", err)
+	}
+
+	fake.grow("BBBB")
+	fake.allow()
+
+	select {
+	case chunk := <-ch:
+		if chunk.Data != "BBBB" {
+			t.Fatalf("first chunk = %q, want %q", chunk.Data, "BBBB")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for first chunk")
+	}
+
+	fake.grow("CCCC")
+	fake.allow()
+
+	select {
+	case chunk := <-ch:
+		if chunk.Data != "CCCC" {
+			t.Fatalf("second chunk = %q, want %q (bytes were skipped)", chunk.Data, "CCCC")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for second chunk")
+	}
+
+	cancel()
+}